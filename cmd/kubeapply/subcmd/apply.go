@@ -0,0 +1,233 @@
+package subcmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/briandowns/spinner"
+	"github.com/segmentio/kubeapply/pkg/cluster"
+	"github.com/segmentio/kubeapply/pkg/cluster/kube"
+	"github.com/segmentio/kubeapply/pkg/config"
+	"github.com/segmentio/kubeapply/pkg/logging"
+	"github.com/segmentio/kubeapply/pkg/util"
+	"github.com/segmentio/kubeapply/pkg/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var applyCmd = &cobra.Command{
+	Use:   "apply [cluster configs]",
+	Short: "apply applies the local configs to the API state",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  applyRun,
+}
+
+type applyFlags struct {
+	// Expand before running apply.
+	expand bool
+
+	// Path to kubeconfig. If unset, tries to fetch from the environment.
+	kubeConfig string
+
+	// Context to use from the kubeconfig. If unset, the kubeconfig's
+	// current-context is used.
+	context string
+
+	// Namespace override applied to all operations for this run.
+	namespace string
+
+	// Run operatation in just a subset of the subdirectories of the expanded configs
+	// (typically maps to namespace). Globs are allowed. If unset, considers all configs.
+	subpaths []string
+}
+
+var applyFlagValues applyFlags
+
+func init() {
+	applyCmd.Flags().BoolVar(
+		&applyFlagValues.expand,
+		"expand",
+		false,
+		"Expand before running apply",
+	)
+	applyCmd.Flags().StringVar(
+		&applyFlagValues.kubeConfig,
+		"kubeconfig",
+		"",
+		"Path to kubeconfig",
+	)
+	applyCmd.Flags().StringVar(
+		&applyFlagValues.context,
+		"context",
+		"",
+		"Kubeconfig context to use; defaults to the kubeconfig's current-context",
+	)
+	applyCmd.Flags().StringVar(
+		&applyFlagValues.namespace,
+		"namespace",
+		"",
+		"Namespace override applied to all operations for this run",
+	)
+	applyCmd.Flags().StringArrayVar(
+		&applyFlagValues.subpaths,
+		"subpath",
+		[]string{},
+		"Apply for expanded configs in the provided subpath(s) only",
+	)
+
+	RootCmd.AddCommand(applyCmd)
+}
+
+func applyRun(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	for _, arg := range args {
+		paths, err := filepath.Glob(arg)
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			if err := applyClusterPath(ctx, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyClusterPath(ctx context.Context, path string) error {
+	clusterConfig, err := config.LoadClusterConfig(path, "")
+	if err != nil {
+		return err
+	}
+	if err := clusterConfig.CheckVersion(version.Version); err != nil {
+		return err
+	}
+
+	if applyFlagValues.expand {
+		if err := expandCluster(ctx, clusterConfig, false); err != nil {
+			return err
+		}
+	}
+
+	log.Infof("Applying cluster %s", clusterConfig.DescriptiveName())
+
+	ok, err := util.DirExists(clusterConfig.ExpandedPath)
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf(
+			"Expanded path %s does not exist",
+			clusterConfig.ExpandedPath,
+		)
+	}
+
+	kubeConfig := applyFlagValues.kubeConfig
+	if kubeConfig == "" {
+		kubeConfig = os.Getenv("KUBECONFIG")
+		if kubeConfig == "" {
+			return errors.New("Must either set --kubeconfig flag or KUBECONFIG env variable")
+		}
+	}
+
+	matches := kube.KubeconfigMatchesCluster(kubeConfig, applyFlagValues.context, clusterConfig.Cluster)
+	if !matches {
+		return fmt.Errorf(
+			"Kubeconfig in %s (context %q) does not appear to reference cluster %s",
+			kubeConfig,
+			applyFlagValues.context,
+			clusterConfig.Cluster,
+		)
+	}
+
+	clusterConfig.KubeConfigPath = kubeConfig
+	clusterConfig.KubeContext = applyFlagValues.context
+	clusterConfig.Namespace = applyFlagValues.namespace
+	clusterConfig.Subpaths = applyFlagValues.subpaths
+
+	return execApply(ctx, clusterConfig)
+}
+
+func execApply(ctx context.Context, clusterConfig *config.ClusterConfig) error {
+	logging.LogResourceEvent(logging.ResourceEvent{
+		Stage: "start",
+		Name:  clusterConfig.DescriptiveName(),
+	})
+	start := time.Now()
+
+	spinnerObj := spinner.New(
+		spinner.CharSets[spinnerCharSet],
+		spinnerDuration,
+		spinner.WithWriter(os.Stderr),
+		spinner.WithHiddenCursor(true),
+	)
+	spinnerObj.Prefix = "Running: "
+
+	kubeClient, err := cluster.NewKubeClusterClient(
+		ctx,
+		&cluster.ClusterClientConfig{
+			CheckApplyConsistency: true,
+			ClusterConfig:         clusterConfig,
+			Debug:                 debug,
+			SpinnerObj:            spinnerObj,
+			// TODO: Make locking an option
+			UseLocks: false,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer kubeClient.Close()
+
+	// If a cluster UID was provided, verify that the cluster we are operating on
+	// has this same UID. Otherwise bail.
+	if clusterConfig.UID != "" {
+		actualUID, err := kubeClient.GetNamespaceUID(ctx, "kube-system")
+		if err != nil {
+			return err
+		}
+
+		if clusterConfig.UID != actualUID {
+			return fmt.Errorf(
+				"Kubeapply config does not match this cluster (wrong kube context?): kube-system uids do not match (%s!=%s)",
+				clusterConfig.UID,
+				actualUID,
+			)
+		}
+
+		kube.LogResolvedContext(clusterConfig.KubeContext, clusterConfig.Cluster, actualUID)
+	}
+
+	results, err := kubeClient.DiffStructured(
+		ctx,
+		clusterConfig.AbsSubpaths(),
+		clusterConfig.ServerSideApply,
+		"",
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := kubeClient.Apply(ctx, clusterConfig.AbsSubpaths(), clusterConfig.ServerSideApply); err != nil {
+		return err
+	}
+
+	for _, result := range results {
+		logging.LogResourceEvent(logging.ResourceEvent{
+			Stage:          "applied",
+			Kind:           result.Kind,
+			Namespace:      result.Namespace,
+			Name:           result.Name,
+			DiffLinesCount: len(result.Added) + len(result.Removed) + len(result.Modified),
+			Duration:       time.Since(start),
+		})
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/segmentio/kubeapply/pkg/cluster/diff"
 	"github.com/segmentio/kubeapply/pkg/cluster/kube"
 	"github.com/segmentio/kubeapply/pkg/config"
+	"github.com/segmentio/kubeapply/pkg/logging"
 	"github.com/segmentio/kubeapply/pkg/util"
 	"github.com/segmentio/kubeapply/pkg/version"
 	log "github.com/sirupsen/logrus"
@@ -44,6 +45,20 @@ type diffFlags struct {
 	// Run operatation in just a subset of the subdirectories of the expanded configs
 	// (typically maps to namespace). Globs are allowed. If unset, considers all configs.
 	subpaths []string
+
+	// Whether to use the native, in-process diff implementation instead of
+	// shelling out to "kubectl diff".
+	nativeDiff bool
+
+	// Context to use from the kubeconfig. If unset, the kubeconfig's
+	// current-context is used.
+	context string
+
+	// Namespace override applied to all operations for this run.
+	namespace string
+
+	// Output format for the diff: text, unified, sidebyside, json, junit, or sarif.
+	output string
 }
 
 var diffFlagValues diffFlags
@@ -73,6 +88,30 @@ func init() {
 		[]string{},
 		"Diff for expanded configs in the provided subpath(s) only",
 	)
+	diffCmd.Flags().BoolVar(
+		&diffFlagValues.nativeDiff,
+		"native-diff",
+		false,
+		"Use the native, in-process diff implementation instead of shelling out to kubectl",
+	)
+	diffCmd.Flags().StringVar(
+		&diffFlagValues.context,
+		"context",
+		"",
+		"Kubeconfig context to use; defaults to the kubeconfig's current-context",
+	)
+	diffCmd.Flags().StringVar(
+		&diffFlagValues.namespace,
+		"namespace",
+		"",
+		"Namespace override applied to all operations for this run",
+	)
+	diffCmd.Flags().StringVar(
+		&diffFlagValues.output,
+		"output",
+		"text",
+		"Diff output format: text, unified, sidebyside, json, junit, or sarif",
+	)
 
 	RootCmd.AddCommand(diffCmd)
 }
@@ -132,19 +171,27 @@ func diffClusterPath(ctx context.Context, path string) error {
 		}
 	}
 
-	matches := kube.KubeconfigMatchesCluster(kubeConfig, clusterConfig.Cluster)
+	matches := kube.KubeconfigMatchesCluster(kubeConfig, diffFlagValues.context, clusterConfig.Cluster)
 	if !matches {
 		return fmt.Errorf(
-			"Kubeconfig in %s does not appear to reference cluster %s",
+			"Kubeconfig in %s (context %q) does not appear to reference cluster %s",
 			kubeConfig,
+			diffFlagValues.context,
 			clusterConfig.Cluster,
 		)
 	}
 
 	clusterConfig.KubeConfigPath = kubeConfig
+	clusterConfig.KubeContext = diffFlagValues.context
+	clusterConfig.Namespace = diffFlagValues.namespace
 	clusterConfig.Subpaths = diffFlagValues.subpaths
 
-	results, rawDiffs, err := execDiff(ctx, clusterConfig, diffFlagValues.simpleOutput)
+	results, rawDiffs, err := execDiff(
+		ctx,
+		clusterConfig,
+		diffFlagValues.simpleOutput,
+		diffFlagValues.nativeDiff,
+	)
 	if err != nil {
 		log.Errorf("Error running diff: %+v", err)
 		log.Info(
@@ -154,7 +201,23 @@ func diffClusterPath(ctx context.Context, path string) error {
 	}
 
 	if results != nil {
-		diff.PrintFull(results)
+		renderer, err := diff.GetRenderer(diffFlagValues.output)
+		if err != nil {
+			return err
+		}
+		if err := renderer.Render(os.Stdout, results); err != nil {
+			return err
+		}
+
+		for _, result := range results {
+			logging.LogResourceEvent(logging.ResourceEvent{
+				Stage:          "diff",
+				Kind:           result.Kind,
+				Namespace:      result.Namespace,
+				Name:           result.Name,
+				DiffLinesCount: len(result.Added) + len(result.Removed) + len(result.Modified),
+			})
+		}
 	} else {
 		log.Infof("Raw diff results:\n%s", rawDiffs)
 	}
@@ -166,7 +229,17 @@ func execDiff(
 	ctx context.Context,
 	clusterConfig *config.ClusterConfig,
 	simpleOutput bool,
+	nativeDiff bool,
 ) ([]diff.Result, string, error) {
+	start := time.Now()
+	defer func() {
+		logging.LogResourceEvent(logging.ResourceEvent{
+			Stage:    "diff-complete",
+			Name:     clusterConfig.DescriptiveName(),
+			Duration: time.Since(start),
+		})
+	}()
+
 	log.Info("Generating diff against versions in Kube API")
 
 	spinnerObj := spinner.New(
@@ -208,6 +281,8 @@ func execDiff(
 				actualUID,
 			)
 		}
+
+		kube.LogResolvedContext(clusterConfig.KubeContext, clusterConfig.Cluster, actualUID)
 	}
 
 	if simpleOutput {
@@ -219,6 +294,15 @@ func execDiff(
 		return nil, string(rawResults), err
 	}
 
+	if nativeDiff {
+		results, err := kubeClient.DiffNative(
+			ctx,
+			clusterConfig.AbsSubpaths(),
+			clusterConfig.ServerSideApply,
+		)
+		return results, "", err
+	}
+
 	results, err := kubeClient.DiffStructured(
 		ctx,
 		clusterConfig.AbsSubpaths(),
@@ -0,0 +1,270 @@
+package subcmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/segmentio/kubeapply/pkg/cluster"
+	"github.com/segmentio/kubeapply/pkg/cluster/diff"
+	"github.com/segmentio/kubeapply/pkg/cluster/kube"
+	"github.com/segmentio/kubeapply/pkg/config"
+	"github.com/segmentio/kubeapply/pkg/util"
+	"github.com/segmentio/kubeapply/pkg/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var driftCmd = &cobra.Command{
+	Use:   "drift [cluster configs]",
+	Short: "drift reports out-of-band changes between the expanded configs and the live cluster state",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  driftRun,
+}
+
+type driftFlags struct {
+	// Path to kubeconfig. If unset, tries to fetch from the environment.
+	kubeConfig string
+
+	// Whether to keep watching for drift via informers instead of running once and exiting.
+	watch bool
+
+	// Address to serve Prometheus metrics on while watching. Only used with --watch.
+	metricsAddr string
+
+	// JSONPaths of fields to ignore when computing drift, e.g. controller-owned
+	// fields like ".status" or HPA-managed replica counts.
+	ignoreFields []string
+
+	// Context to use from the kubeconfig. If unset, the kubeconfig's
+	// current-context is used.
+	context string
+}
+
+var driftFlagValues driftFlags
+
+var driftResourcesGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "kubeapply_drift_resources",
+		Help: "Set to 1 once a managed resource has been seen to differ from its desired state while watching; not reset when it converges again",
+	},
+	[]string{"namespace", "kind", "name"},
+)
+
+func init() {
+	driftCmd.Flags().StringVar(
+		&driftFlagValues.kubeConfig,
+		"kubeconfig",
+		"",
+		"Path to kubeconfig",
+	)
+	driftCmd.Flags().BoolVar(
+		&driftFlagValues.watch,
+		"watch",
+		false,
+		"Keep watching for drift via informers instead of exiting after one pass",
+	)
+	driftCmd.Flags().StringVar(
+		&driftFlagValues.metricsAddr,
+		"metrics-addr",
+		":9090",
+		"Address to serve Prometheus drift metrics on; only used with --watch",
+	)
+	driftCmd.Flags().StringArrayVar(
+		&driftFlagValues.ignoreFields,
+		"ignore-fields",
+		[]string{},
+		"JSONPath(s) of fields to ignore when computing drift",
+	)
+	driftCmd.Flags().StringVar(
+		&driftFlagValues.context,
+		"context",
+		"",
+		"Kubeconfig context to use; defaults to the kubeconfig's current-context",
+	)
+
+	prometheus.MustRegister(driftResourcesGauge)
+
+	RootCmd.AddCommand(driftCmd)
+}
+
+func driftRun(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	for _, arg := range args {
+		paths, err := filepath.Glob(arg)
+		if err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			if err := driftClusterPath(ctx, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func driftClusterPath(ctx context.Context, path string) error {
+	clusterConfig, err := config.LoadClusterConfig(path, "")
+	if err != nil {
+		return err
+	}
+	if err := clusterConfig.CheckVersion(version.Version); err != nil {
+		return err
+	}
+
+	log.Infof("Checking drift for cluster %s", clusterConfig.DescriptiveName())
+
+	ok, err := util.DirExists(clusterConfig.ExpandedPath)
+	if err != nil {
+		return err
+	} else if !ok {
+		return fmt.Errorf(
+			"Expanded path %s does not exist",
+			clusterConfig.ExpandedPath,
+		)
+	}
+
+	kubeConfig := driftFlagValues.kubeConfig
+	if kubeConfig == "" {
+		kubeConfig = os.Getenv("KUBECONFIG")
+		if kubeConfig == "" {
+			return fmt.Errorf("Must either set --kubeconfig flag or KUBECONFIG env variable")
+		}
+	}
+
+	matches := kube.KubeconfigMatchesCluster(kubeConfig, driftFlagValues.context, clusterConfig.Cluster)
+	if !matches {
+		return fmt.Errorf(
+			"Kubeconfig in %s (context %q) does not appear to reference cluster %s",
+			kubeConfig,
+			driftFlagValues.context,
+			clusterConfig.Cluster,
+		)
+	}
+
+	clusterConfig.KubeConfigPath = kubeConfig
+	clusterConfig.KubeContext = driftFlagValues.context
+
+	kubeClient, err := cluster.NewKubeClusterClient(
+		ctx,
+		&cluster.ClusterClientConfig{
+			CheckApplyConsistency: false,
+			ClusterConfig:         clusterConfig,
+			Debug:                 debug,
+			UseLocks:              false,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	defer kubeClient.Close()
+
+	if driftFlagValues.watch {
+		go serveDriftMetrics(driftFlagValues.metricsAddr)
+		return watchDrift(ctx, kubeClient, clusterConfig, driftFlagValues.ignoreFields)
+	}
+
+	results, err := driftOnce(ctx, kubeClient, clusterConfig, driftFlagValues.ignoreFields)
+	if err != nil {
+		return err
+	}
+
+	if len(results) > 0 {
+		diff.PrintFull(results)
+		return fmt.Errorf("found drift in %d resource(s)", len(results))
+	}
+
+	log.Info("No drift detected")
+	return nil
+}
+
+// driftOnce runs a single drift check and returns the set of resources that
+// have diverged from their desired, expanded state.
+func driftOnce(
+	ctx context.Context,
+	kubeClient *cluster.KubeClient,
+	clusterConfig *config.ClusterConfig,
+	ignoreFields []string,
+) ([]diff.Result, error) {
+	results, err := kubeClient.DiffStructured(
+		ctx,
+		clusterConfig.AbsSubpaths(),
+		clusterConfig.ServerSideApply,
+		"",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff.FilterIgnoredFields(results, ignoreFields), nil
+}
+
+// watchDrift uses informers on the discovered GVRs for the expanded configs
+// to emit drift events as JSON lines whenever a managed object diverges
+// from the expanded manifests, until the context is cancelled.
+func watchDrift(
+	ctx context.Context,
+	kubeClient *cluster.KubeClient,
+	clusterConfig *config.ClusterConfig,
+	ignoreFields []string,
+) error {
+	events := make(chan diff.Result)
+
+	// WatchDrift owns stopCh and closes it itself once ctx is done; the
+	// caller must not also close it, or the two races to close the same
+	// channel.
+	_, err := kubeClient.WatchDrift(ctx, clusterConfig.AbsSubpaths(), ignoreFields, events)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			driftResourcesGauge.WithLabelValues(
+				event.Namespace,
+				event.Kind,
+				event.Name,
+			).Set(1)
+
+			if err := encoder.Encode(event); err != nil {
+				log.Errorf("Error encoding drift event: %+v", err)
+			}
+		}
+	}
+}
+
+func serveDriftMetrics(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+
+	log.Infof("Serving drift metrics on %s/metrics", addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Errorf("Error serving drift metrics: %+v", err)
+	}
+}
@@ -0,0 +1,27 @@
+package subcmd
+
+import (
+	"github.com/segmentio/kubeapply/pkg/logging"
+	"github.com/spf13/cobra"
+)
+
+var logFormat string
+
+func init() {
+	RootCmd.PersistentFlags().StringVar(
+		&logFormat,
+		"log-format",
+		string(logging.FormatTerraform),
+		"Log output format: terraform, json, or logfmt",
+	)
+	previousPreRunE := RootCmd.PersistentPreRunE
+	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if previousPreRunE != nil {
+			if err := previousPreRunE(cmd, args); err != nil {
+				return err
+			}
+		}
+
+		return logging.Configure(logFormat)
+	}
+}
@@ -2,18 +2,20 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/segmentio/kubeapply/pkg/logging"
 	"github.com/segmentio/kubeapply/pkg/provider"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
 var (
-	debug bool
+	debug     bool
+	logFormat string
 
 	rootCmd = &cobra.Command{
 		Use:   "terraform-provider-kubeapply",
@@ -29,17 +31,25 @@ func init() {
 		false,
 		"Run in debug mode",
 	)
+	rootCmd.Flags().StringVar(
+		&logFormat,
+		"log-format",
+		envOrDefault("KUBEAPPLY_LOG_FORMAT", string(logging.FormatTerraform)),
+		"Log output format: terraform, json, or logfmt",
+	)
 
-	// Terraform requires a very simple log output format; see
-	// https://www.terraform.io/docs/extend/debugging.html#inserting-log-lines-into-a-provider
-	// for more details.
-	log.SetFormatter(&simpleFormatter{})
 	log.SetLevel(log.InfoLevel)
 }
 
 func runProvider(cmd *cobra.Command, args []string) error {
+	if err := logging.Configure(logFormat); err != nil {
+		return err
+	}
+
 	if debug {
 		log.SetLevel(log.DebugLevel)
+	} else if level, ok := tfLogLevel(); ok {
+		log.SetLevel(level)
 	}
 
 	log.Info("Starting kubeapply provider")
@@ -66,15 +76,38 @@ func main() {
 	}
 }
 
-type simpleFormatter struct {
+// tfLogLevel maps Terraform's TF_LOG_PROVIDER (preferred, provider-specific)
+// or TF_LOG env vars to a logrus level, so that "terraform apply" verbosity
+// propagates into kubeapply's own log level instead of always forcing debug
+// output. The second return value is false when neither var is set, or it is
+// set to "OFF" or to an unrecognized value, in which case the caller should
+// leave the level at its existing default.
+func tfLogLevel() (log.Level, bool) {
+	value := os.Getenv("TF_LOG_PROVIDER")
+	if value == "" {
+		value = os.Getenv("TF_LOG")
+	}
+
+	switch strings.ToUpper(value) {
+	case "TRACE":
+		return log.TraceLevel, true
+	case "DEBUG":
+		return log.DebugLevel, true
+	case "INFO":
+		return log.InfoLevel, true
+	case "WARN":
+		return log.WarnLevel, true
+	case "ERROR":
+		return log.ErrorLevel, true
+	default:
+		// "", "OFF", or anything unrecognized: leave the level untouched.
+		return log.InfoLevel, false
+	}
 }
 
-func (s *simpleFormatter) Format(entry *log.Entry) ([]byte, error) {
-	return []byte(
-		fmt.Sprintf(
-			"[%s] %s\n",
-			strings.ToUpper(entry.Level.String()),
-			entry.Message,
-		),
-	), nil
+func envOrDefault(envName string, def string) string {
+	if value := os.Getenv(envName); value != "" {
+		return value
+	}
+	return def
 }
@@ -0,0 +1,52 @@
+// Package diff contains the structured representation of a diff between a
+// manifest's desired state and its live state in the cluster, along with the
+// renderers that turn that representation into output for a human or a CI
+// system.
+package diff
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// Result is a structured diff for a single resource. Added, Removed, and
+// Modified hold JSONPath-style field paths (e.g. ".spec.replicas") relative
+// to the object root.
+type Result struct {
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace"`
+	Kind      string   `json:"kind"`
+	Source    string   `json:"source,omitempty"`
+	Added     []string `json:"added,omitempty"`
+	Removed   []string `json:"removed,omitempty"`
+	Modified  []string `json:"modified,omitempty"`
+}
+
+// Results wraps a slice of Result for JSON (de)serialization at the top
+// level, e.g. when passing structured diffs between the "kdiff" helper and
+// its caller.
+type Results struct {
+	Results []Result `json:"results"`
+}
+
+// PrintFull prints a human-readable rendering of results to stdout.
+func PrintFull(results []Result) {
+	renderer := &textPrinter{}
+	renderer.print(os.Stdout, results)
+}
+
+// DiffKube shells out to "kubectl diff"-style comparison between the
+// manifests at oldPath and newPath, returning the results in the same
+// structured form as the native diff path. Each result describes one
+// differing file, with Modified set to "contents"; shortDiff leaves Modified
+// empty, so that only the name and source of each file are reported.
+func DiffKube(oldPath string, newPath string, shortDiff bool) ([]Result, error) {
+	cmd := exec.Command("diff", "-rq", oldPath, newPath)
+	output, err := cmd.CombinedOutput()
+	if err != nil && cmd.ProcessState.ExitCode() > 1 {
+		return nil, fmt.Errorf("error diffing %s and %s: %+v (%s)", oldPath, newPath, err, output)
+	}
+
+	return parseDiffOutput(output, shortDiff), nil
+}
@@ -0,0 +1,67 @@
+package diff
+
+import "strings"
+
+// FilterIgnoredFields removes entries from each Result's Added/Removed/Modified
+// field lists that match one of the provided JSONPath prefixes, e.g.
+// ".status" or ".spec.replicas". Results that have no remaining diffs after
+// filtering are dropped entirely.
+func FilterIgnoredFields(results []Result, ignoreFields []string) []Result {
+	if len(ignoreFields) == 0 {
+		return results
+	}
+
+	filtered := []Result{}
+
+	for _, result := range results {
+		result.Added = filterFieldPaths(result.Added, ignoreFields)
+		result.Removed = filterFieldPaths(result.Removed, ignoreFields)
+		result.Modified = filterFieldPaths(result.Modified, ignoreFields)
+
+		if len(result.Added)+len(result.Removed)+len(result.Modified) > 0 {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
+
+// FilterIgnoredFieldsSingle applies the same filtering as FilterIgnoredFields
+// to a single Result, without the need to re-wrap it in and index back out
+// of a slice. The second return value reports whether any diff remains
+// after filtering.
+func FilterIgnoredFieldsSingle(result Result, ignoreFields []string) (Result, bool) {
+	filtered := FilterIgnoredFields([]Result{result}, ignoreFields)
+	if len(filtered) == 0 {
+		return Result{}, false
+	}
+
+	remaining := filtered[0]
+	return remaining, len(remaining.Added)+len(remaining.Removed)+len(remaining.Modified) > 0
+}
+
+func filterFieldPaths(paths []string, ignoreFields []string) []string {
+	kept := []string{}
+
+	for _, path := range paths {
+		if !matchesAnyPrefix(path, ignoreFields) {
+			kept = append(kept, path)
+		}
+	}
+
+	return kept
+}
+
+// matchesAnyPrefix reports whether path is exactly one of ignoreFields, or a
+// descendant of one. Plain strings.HasPrefix would also match unrelated
+// sibling fields that merely share a string prefix, e.g. ".metadata.name"
+// matching ".metadata.namespace".
+func matchesAnyPrefix(path string, ignoreFields []string) bool {
+	for _, ignoreField := range ignoreFields {
+		if path == ignoreField || strings.HasPrefix(path, ignoreField+".") {
+			return true
+		}
+	}
+
+	return false
+}
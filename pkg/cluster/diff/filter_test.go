@@ -0,0 +1,48 @@
+package diff
+
+import "testing"
+
+func TestFilterIgnoredFieldsSingleAllIgnored(t *testing.T) {
+	result := Result{
+		Name:     "my-deployment",
+		Kind:     "Deployment",
+		Modified: []string{".status.replicas"},
+	}
+
+	filtered, changed := FilterIgnoredFieldsSingle(result, []string{".status"})
+	if changed {
+		t.Fatalf("expected changed=false once all diffs are ignored, got result %+v", filtered)
+	}
+}
+
+func TestFilterIgnoredFieldsSingleKeepsRemaining(t *testing.T) {
+	result := Result{
+		Name:     "my-deployment",
+		Kind:     "Deployment",
+		Modified: []string{".status.replicas", ".spec.replicas"},
+	}
+
+	filtered, changed := FilterIgnoredFieldsSingle(result, []string{".status"})
+	if !changed {
+		t.Fatal("expected changed=true since .spec.replicas is not ignored")
+	}
+	if len(filtered.Modified) != 1 || filtered.Modified[0] != ".spec.replicas" {
+		t.Fatalf("expected only .spec.replicas to remain, got %+v", filtered.Modified)
+	}
+}
+
+func TestFilterIgnoredFieldsSingleDoesNotMatchSiblingField(t *testing.T) {
+	result := Result{
+		Name:     "my-namespace",
+		Kind:     "Namespace",
+		Modified: []string{".metadata.namespace"},
+	}
+
+	filtered, changed := FilterIgnoredFieldsSingle(result, []string{".metadata.name"})
+	if !changed {
+		t.Fatal("expected .metadata.namespace to survive filtering on .metadata.name")
+	}
+	if len(filtered.Modified) != 1 || filtered.Modified[0] != ".metadata.namespace" {
+		t.Fatalf("expected .metadata.namespace to remain, got %+v", filtered.Modified)
+	}
+}
@@ -0,0 +1,125 @@
+package diff
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// sourceAnnotation is set by the expand step on every manifest with the
+// on-disk path it was rendered from, so that diffs can be traced back to the
+// file that produced them (e.g. for SARIF annotations in CI).
+const sourceAnnotation = "kubeapply.segment.io/source"
+
+// ignoredFields are top-level, server-managed fields that are never
+// meaningful in a diff between the live object and a dry-run apply result.
+var ignoredFields = [][]string{
+	{"metadata", "managedFields"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "generation"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "uid"},
+	{"status"},
+}
+
+// DiffUnstructured compares a live object against the result of a
+// server-side apply dry run and returns a structured Result describing any
+// added, removed, or modified fields. The second return value indicates
+// whether any non-ignored difference was found; if live is nil, the object
+// is treated as not yet existing and every field in dryRun is reported as
+// added.
+func DiffUnstructured(live, dryRun *unstructured.Unstructured) (Result, bool, error) {
+	liveContent := map[string]interface{}{}
+	if live != nil {
+		liveContent = stripIgnoredFields(live.DeepCopy().Object)
+	}
+	dryRunContent := stripIgnoredFields(dryRun.DeepCopy().Object)
+
+	added, removed, modified := diffFields("", liveContent, dryRunContent)
+
+	result := Result{
+		Name:      dryRun.GetName(),
+		Namespace: dryRun.GetNamespace(),
+		Kind:      dryRun.GetKind(),
+		Source:    dryRun.GetAnnotations()[sourceAnnotation],
+		Added:     added,
+		Removed:   removed,
+		Modified:  modified,
+	}
+
+	return result, len(added)+len(removed)+len(modified) > 0, nil
+}
+
+// diffFields recursively walks two field maps and reports the JSONPath-style
+// keys that were added, removed, or modified between them.
+func diffFields(
+	prefix string,
+	live map[string]interface{},
+	dryRun map[string]interface{},
+) (added []string, removed []string, modified []string) {
+	for key, dryRunValue := range dryRun {
+		path := fmt.Sprintf("%s.%s", prefix, key)
+
+		liveValue, ok := live[key]
+		if !ok {
+			added = append(added, path)
+			continue
+		}
+
+		liveMap, liveIsMap := liveValue.(map[string]interface{})
+		dryRunMap, dryRunIsMap := dryRunValue.(map[string]interface{})
+
+		if liveIsMap && dryRunIsMap {
+			childAdded, childRemoved, childModified := diffFields(path, liveMap, dryRunMap)
+			added = append(added, childAdded...)
+			removed = append(removed, childRemoved...)
+			modified = append(modified, childModified...)
+			continue
+		}
+
+		if !deepEqual(liveValue, dryRunValue) {
+			modified = append(modified, path)
+		}
+	}
+
+	for key := range live {
+		if _, ok := dryRun[key]; !ok {
+			removed = append(removed, fmt.Sprintf("%s.%s", prefix, key))
+		}
+	}
+
+	return added, removed, modified
+}
+
+func stripIgnoredFields(obj map[string]interface{}) map[string]interface{} {
+	cleaned := map[string]interface{}{}
+	for key, value := range obj {
+		cleaned[key] = value
+	}
+
+	for _, fieldPath := range ignoredFields {
+		removeNestedField(cleaned, fieldPath)
+	}
+
+	return cleaned
+}
+
+func removeNestedField(obj map[string]interface{}, fieldPath []string) {
+	current := obj
+	for i, field := range fieldPath {
+		if i == len(fieldPath)-1 {
+			delete(current, field)
+			return
+		}
+
+		next, ok := current[field].(map[string]interface{})
+		if !ok {
+			return
+		}
+		current = next
+	}
+}
+
+func deepEqual(a, b interface{}) bool {
+	return fmt.Sprintf("%v", a) == fmt.Sprintf("%v", b)
+}
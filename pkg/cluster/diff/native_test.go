@@ -0,0 +1,128 @@
+package diff
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDiffUnstructuredNoChange(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "my-deployment"},
+			"kind":     "Deployment",
+			"spec":     map[string]interface{}{"replicas": int64(3)},
+		},
+	}
+
+	_, changed, err := DiffUnstructured(obj, obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if changed {
+		t.Fatal("expected no diff when live and dry-run objects are identical")
+	}
+}
+
+func TestDiffUnstructuredDetectsModifiedAndAdded(t *testing.T) {
+	live := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "my-deployment"},
+			"kind":     "Deployment",
+			"spec":     map[string]interface{}{"replicas": int64(3)},
+		},
+	}
+	dryRun := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "my-deployment"},
+			"kind":     "Deployment",
+			"spec": map[string]interface{}{
+				"replicas": int64(5),
+				"paused":   true,
+			},
+		},
+	}
+
+	result, changed, err := DiffUnstructured(live, dryRun)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !changed {
+		t.Fatal("expected a diff between differing replica counts")
+	}
+	if len(result.Modified) != 1 || result.Modified[0] != ".spec.replicas" {
+		t.Fatalf("expected .spec.replicas to be modified, got %+v", result.Modified)
+	}
+	if len(result.Added) != 1 || result.Added[0] != ".spec.paused" {
+		t.Fatalf("expected .spec.paused to be added, got %+v", result.Added)
+	}
+}
+
+func TestDiffUnstructuredNilLiveTreatsAllFieldsAsAdded(t *testing.T) {
+	dryRun := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{"name": "my-deployment"},
+			"kind":     "Deployment",
+			"spec":     map[string]interface{}{"replicas": int64(3)},
+		},
+	}
+
+	result, changed, err := DiffUnstructured(nil, dryRun)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if !changed {
+		t.Fatal("expected a diff when the object doesn't exist yet")
+	}
+	if len(result.Added) == 0 {
+		t.Fatalf("expected fields to be reported as added, got %+v", result)
+	}
+}
+
+func TestDiffUnstructuredIgnoresServerManagedFields(t *testing.T) {
+	live := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":              "my-deployment",
+				"resourceVersion":   "111",
+				"generation":        int64(1),
+				"creationTimestamp": "2020-01-01T00:00:00Z",
+				"uid":               "abc-123",
+				"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			},
+			"kind":   "Deployment",
+			"spec":   map[string]interface{}{"replicas": int64(3)},
+			"status": map[string]interface{}{"readyReplicas": int64(3)},
+		},
+	}
+	dryRun := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":              "my-deployment",
+				"resourceVersion":   "222",
+				"generation":        int64(2),
+				"creationTimestamp": "2020-01-02T00:00:00Z",
+				"uid":               "abc-123",
+				"managedFields":     []interface{}{map[string]interface{}{"manager": "kubeapply"}},
+			},
+			"kind":   "Deployment",
+			"spec":   map[string]interface{}{"replicas": int64(3)},
+			"status": map[string]interface{}{"readyReplicas": int64(0)},
+		},
+	}
+
+	_, changed, err := DiffUnstructured(live, dryRun)
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	if changed {
+		t.Fatal("expected no diff when only server-managed fields (resourceVersion, managedFields, status, ...) differ")
+	}
+
+	if _, ok := live.Object["metadata"].(map[string]interface{})["resourceVersion"]; !ok {
+		t.Fatal("expected DiffUnstructured not to mutate the caller's live object")
+	}
+	if _, ok := live.Object["status"].(map[string]interface{})["readyReplicas"]; !ok {
+		t.Fatal("expected DiffUnstructured not to strip fields from the caller's live object")
+	}
+}
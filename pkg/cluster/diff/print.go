@@ -0,0 +1,79 @@
+package diff
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// textPrinter renders results the way they've always looked on a developer's
+// terminal: one block per resource, with the changed field paths grouped by
+// whether they were added, removed, or modified.
+type textPrinter struct{}
+
+func (t *textPrinter) print(w io.Writer, results []Result) {
+	for _, result := range results {
+		fmt.Fprintf(w, "--- %s/%s ---\n", result.Kind, result.Name)
+
+		for _, path := range result.Removed {
+			fmt.Fprintf(w, "  - %s\n", path)
+		}
+		for _, path := range result.Modified {
+			fmt.Fprintf(w, "  ~ %s\n", path)
+		}
+		for _, path := range result.Added {
+			fmt.Fprintf(w, "  + %s\n", path)
+		}
+	}
+}
+
+// parseDiffOutput turns the output of a recursive "diff -rq" run into a
+// coarse-grained Result per differing file. This is a fallback path used
+// only when the native, structured diff pipeline isn't available.
+func parseDiffOutput(output []byte, shortDiff bool) []Result {
+	results := []Result{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "Files ") && !strings.HasPrefix(line, "Only in") {
+			continue
+		}
+
+		result := Result{
+			Name:     line,
+			Source:   sourceFromDiffLine(line),
+			Modified: []string{"contents"},
+		}
+		if shortDiff {
+			result.Modified = nil
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// sourceFromDiffLine extracts a file path out of a "diff -rq" summary line,
+// so that each Result can still be traced back to the manifest that
+// produced it: the new-side path for "Files a/x and b/x differ" -> "b/x",
+// and the path of the only existing file for "Only in <dir>: <file>" lines,
+// on whichever side that file is.
+func sourceFromDiffLine(line string) string {
+	fields := strings.Fields(line)
+
+	switch {
+	case strings.HasPrefix(line, "Files ") && len(fields) >= 4:
+		// "Files <old> and <new> differ"
+		return fields[3]
+	case strings.HasPrefix(line, "Only in ") && len(fields) >= 4:
+		// "Only in <dir>: <file>"
+		dir := strings.TrimSuffix(fields[2], ":")
+		return dir + "/" + fields[3]
+	default:
+		return ""
+	}
+}
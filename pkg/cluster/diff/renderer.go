@@ -0,0 +1,51 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+)
+
+// Renderer turns a set of structured diff Results into an output format
+// suitable for a particular consumer, e.g. a human terminal or a CI system.
+type Renderer interface {
+	// Render writes the results to w in this renderer's format. It returns
+	// an error only for write failures; a non-empty diff is not itself an
+	// error.
+	Render(w io.Writer, results []Result) error
+}
+
+// renderers maps --output flag values to the Renderer that implements them.
+var renderers = map[string]Renderer{
+	"text":       &textRenderer{},
+	"unified":    &unifiedRenderer{},
+	"sidebyside": &sideBySideRenderer{},
+	"json":       &jsonRenderer{},
+	"junit":      &junitRenderer{},
+	"sarif":      &sarifRenderer{},
+}
+
+// GetRenderer looks up the Renderer registered for the given --output flag
+// value. An empty name returns the default "text" renderer.
+func GetRenderer(name string) (Renderer, error) {
+	if name == "" {
+		name = "text"
+	}
+
+	renderer, ok := renderers[name]
+	if !ok {
+		return nil, fmt.Errorf(
+			"unrecognized diff output format %q, expected one of: text, unified, sidebyside, json, junit, sarif",
+			name,
+		)
+	}
+
+	return renderer, nil
+}
+
+// textRenderer reproduces the existing human-readable output of PrintFull.
+type textRenderer struct{}
+
+func (t *textRenderer) Render(w io.Writer, results []Result) error {
+	(&textPrinter{}).print(w, results)
+	return nil
+}
@@ -0,0 +1,22 @@
+package diff
+
+import (
+	"io"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// jsonRenderer marshals the results using the same Results wrapper that
+// "kdiff" already produces, so downstream tooling has a single JSON shape
+// to parse regardless of which code path generated the diff.
+type jsonRenderer struct{}
+
+func (j *jsonRenderer) Render(w io.Writer, results []Result) error {
+	jsonBytes, err := json.MarshalIndent(Results{Results: results}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(jsonBytes, '\n'))
+	return err
+}
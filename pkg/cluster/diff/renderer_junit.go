@@ -0,0 +1,72 @@
+package diff
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitTestSuite and junitTestCase model just enough of the JUnit XML schema
+// for CI systems that render JUnit reports; one testcase is emitted per
+// resource, and it fails when that resource has drifted from its desired
+// state.
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name    string        `xml:"name,attr"`
+	Failure *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Content string `xml:",chardata"`
+}
+
+// junitRenderer renders one failing testcase per resource that has drifted,
+// so that CI systems that only understand JUnit XML can surface diffs as
+// test failures.
+type junitRenderer struct{}
+
+func (j *junitRenderer) Render(w io.Writer, results []Result) error {
+	suite := junitTestSuite{
+		Name:  "kubeapply-diff",
+		Tests: len(results),
+	}
+
+	for _, result := range results {
+		testCase := junitTestCase{
+			Name: fmt.Sprintf("%s/%s", result.Kind, result.Name),
+		}
+
+		paths := append(append(append([]string{}, result.Added...), result.Removed...), result.Modified...)
+		if len(paths) > 0 {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("%s/%s has drifted from its desired state", result.Kind, result.Name),
+				Content: strings.Join(paths, "\n"),
+			}
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suite); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
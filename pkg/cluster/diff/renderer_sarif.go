@@ -0,0 +1,127 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/segmentio/encoding/json"
+)
+
+// SARIF 2.1.0 types, kept minimal: just enough to describe one result per
+// drifted field so that GitHub code-scanning can annotate the manifest file
+// that produced it.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string   `json:"name"`
+	InformationURI string   `json:"informationUri"`
+	Rules          []string `json:"rules,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// defaultSarifStartLine is used when a Result isn't associated with a known
+// line within its source manifest; it points code-scanning at the top of
+// the file rather than omitting the region entirely.
+const defaultSarifStartLine = 1
+
+// sarifRenderer renders one SARIF result per drifted field, keyed by the
+// manifest file that produced the resource, so that GitHub code-scanning
+// can annotate PRs with drift findings.
+type sarifRenderer struct{}
+
+func (s *sarifRenderer) Render(w io.Writer, results []Result) error {
+	sarifLogDoc := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{
+			{
+				Tool: sarifTool{
+					Driver: sarifDriver{
+						Name:           "kubeapply",
+						InformationURI: "https://github.com/segmentio/kubeapply",
+					},
+				},
+			},
+		},
+	}
+
+	for _, result := range results {
+		for _, path := range result.Added {
+			sarifLogDoc.Runs[0].Results = append(sarifLogDoc.Runs[0].Results, sarifResultFor(result, "added", path))
+		}
+		for _, path := range result.Removed {
+			sarifLogDoc.Runs[0].Results = append(sarifLogDoc.Runs[0].Results, sarifResultFor(result, "removed", path))
+		}
+		for _, path := range result.Modified {
+			sarifLogDoc.Runs[0].Results = append(sarifLogDoc.Runs[0].Results, sarifResultFor(result, "modified", path))
+		}
+	}
+
+	jsonBytes, err := json.MarshalIndent(sarifLogDoc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(jsonBytes, '\n'))
+	return err
+}
+
+func sarifResultFor(result Result, kind string, path string) sarifResult {
+	return sarifResult{
+		RuleID: fmt.Sprintf("kubeapply/%s", kind),
+		Level:  "warning",
+		Message: sarifMessage{
+			Text: fmt.Sprintf("%s/%s field %s was %s", result.Kind, result.Name, path, kind),
+		},
+		Locations: []sarifLocation{
+			{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{
+						URI: result.Source,
+					},
+					Region: sarifRegion{StartLine: defaultSarifStartLine},
+				},
+			},
+		},
+	}
+}
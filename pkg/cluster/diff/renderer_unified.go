@@ -0,0 +1,78 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+)
+
+// unifiedRenderer renders each Result as a git-style unified patch listing
+// the added, removed, and modified field paths for that object.
+type unifiedRenderer struct{}
+
+func (u *unifiedRenderer) Render(w io.Writer, results []Result) error {
+	for _, result := range results {
+		header := fmt.Sprintf("--- a/%s/%s\n+++ b/%s/%s\n", result.Kind, result.Name, result.Kind, result.Name)
+		if _, err := io.WriteString(w, header); err != nil {
+			return err
+		}
+
+		for _, path := range result.Removed {
+			if _, err := fmt.Fprintf(w, "-%s\n", path); err != nil {
+				return err
+			}
+		}
+		for _, path := range result.Modified {
+			if _, err := fmt.Fprintf(w, "-%s (before)\n+%s (after)\n", path, path); err != nil {
+				return err
+			}
+		}
+		for _, path := range result.Added {
+			if _, err := fmt.Fprintf(w, "+%s\n", path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sideBySideRenderer renders a two-column view of removed or modified field
+// paths (left, in red) and added or modified field paths (right, in green),
+// using ANSI color escapes.
+type sideBySideRenderer struct{}
+
+func (s *sideBySideRenderer) Render(w io.Writer, results []Result) error {
+	const columnWidth = 50
+
+	for _, result := range results {
+		if _, err := fmt.Fprintf(w, "%s/%s\n", result.Kind, result.Name); err != nil {
+			return err
+		}
+
+		left := append(append([]string{}, result.Removed...), result.Modified...)
+		right := append(append([]string{}, result.Added...), result.Modified...)
+
+		for i := 0; i < maxInt(len(left), len(right)); i++ {
+			var leftCol, rightCol string
+			if i < len(left) {
+				leftCol = left[i]
+			}
+			if i < len(right) {
+				rightCol = right[i]
+			}
+
+			if _, err := fmt.Fprintf(w, "\x1b[31m%-*s\x1b[0m | \x1b[32m%s\x1b[0m\n", columnWidth, leftCol, rightCol); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,96 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kubeapply/pkg/cluster/diff"
+	"github.com/segmentio/kubeapply/pkg/util"
+	log "github.com/sirupsen/logrus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DiffNative computes structured diffs between the manifests in paths and the
+// live cluster state without shelling out to "kubectl diff". For each
+// manifest, it fetches the live object (if any) via the dynamic client, then
+// submits the manifest as a server-side apply dry run to obtain the
+// "would-be" object, and diffs the two directly. GVRs are resolved from the
+// discovery client so that CRDs are handled the same way as built-in types.
+func (k *KubeClient) DiffNative(
+	ctx context.Context,
+	paths []string,
+	ssa bool,
+) ([]diff.Result, error) {
+	// ssa controls whether applies outside of this diff use server-side
+	// apply (see DiffStructured); it has no bearing on the dry run below,
+	// which always force-acquires field ownership since a dry run can't
+	// actually mutate anything.
+	objs, err := util.ExpandedObjects(paths, k.clusterConfig.Subpaths)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []diff.Result{}
+
+	for _, obj := range objs {
+		gvr, err := k.gvrForObject(obj)
+		if err != nil {
+			return nil, fmt.Errorf("error resolving GVR for %s: %+v", obj.GetName(), err)
+		}
+
+		resourceClient := k.dynamicClient.Resource(gvr).Namespace(obj.GetNamespace())
+
+		live, err := resourceClient.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("error fetching live object for %s: %+v", obj.GetName(), err)
+		}
+
+		dryRun, err := resourceClient.Apply(
+			ctx,
+			obj.GetName(),
+			obj,
+			metav1.ApplyOptions{
+				FieldManager: kubeapplyFieldManager,
+				DryRun:       []string{metav1.DryRunAll},
+				Force:        true,
+			},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error dry-running apply for %s: %+v", obj.GetName(), err)
+		}
+
+		result, changed, err := diff.DiffUnstructured(live, dryRun)
+		if err != nil {
+			return nil, fmt.Errorf("error diffing %s: %+v", obj.GetName(), err)
+		}
+
+		if changed {
+			log.Debugf("Found native diff for %s/%s", obj.GetKind(), obj.GetName())
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// gvrForObject resolves the GroupVersionResource for an unstructured object
+// using the cluster's discovery client, so that CRDs are diffed the same
+// way as built-in resources.
+func (k *KubeClient) gvrForObject(obj *unstructured.Unstructured) (schema.GroupVersionResource, error) {
+	mapping, err := k.restMapper.RESTMapping(
+		obj.GroupVersionKind().GroupKind(),
+		obj.GroupVersionKind().Version,
+	)
+	if err != nil {
+		return schema.GroupVersionResource{}, err
+	}
+
+	return mapping.Resource, nil
+}
+
+// kubeapplyFieldManager is the field manager name used for server-side
+// apply dry runs issued by the native diff path.
+const kubeapplyFieldManager = "kubeapply"
@@ -0,0 +1,63 @@
+// Package kube contains helpers for inspecting and validating kubeconfig
+// files independently of the main cluster client.
+package kube
+
+import (
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// KubeconfigMatchesCluster checks whether the given kubeconfig references
+// the expected cluster. If contextName is non-empty, that specific context
+// is validated against clusterName instead of the kubeconfig's
+// current-context, which allows callers to target a specific context out of
+// a multi-context kubeconfig.
+func KubeconfigMatchesCluster(kubeConfigPath string, contextName string, clusterName string) bool {
+	config, err := clientcmd.LoadFromFile(kubeConfigPath)
+	if err != nil {
+		log.Debugf("Error loading kubeconfig %s: %+v", kubeConfigPath, err)
+		return false
+	}
+
+	if contextName == "" {
+		contextName = config.CurrentContext
+	}
+
+	context, ok := config.Contexts[contextName]
+	if !ok {
+		log.Debugf("Context %s not found in kubeconfig %s", contextName, kubeConfigPath)
+		return false
+	}
+
+	cluster, ok := config.Clusters[context.Cluster]
+	if !ok {
+		log.Debugf("Cluster %s not found in kubeconfig %s", context.Cluster, kubeConfigPath)
+		return false
+	}
+
+	log.Infof(
+		"Resolved context %s to cluster server %s (expected cluster name %s)",
+		contextName,
+		cluster.Server,
+		clusterName,
+	)
+
+	return context.Cluster == clusterName || cluster.Server == clusterName
+}
+
+// LogResolvedContext records the kubeconfig context, cluster, and cluster
+// UID that a client ended up using, so that the specific context picked out
+// of a multi-context kubeconfig is auditable from logs alongside the
+// cluster identity it was verified against.
+func LogResolvedContext(contextName string, clusterName string, uid string) {
+	if contextName == "" {
+		contextName = "(current-context)"
+	}
+
+	log.Infof(
+		"Verified kubeconfig context %s against cluster %s (uid=%s)",
+		contextName,
+		clusterName,
+		uid,
+	)
+}
@@ -0,0 +1,109 @@
+package cluster
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kubeapply/pkg/cluster/diff"
+	"github.com/segmentio/kubeapply/pkg/util"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+// driftInformerResync is how often the drift informers do a full relist, as
+// a backstop against missed watch events.
+const driftInformerResync = 5 * time.Minute
+
+// WatchDrift starts informers for every GVR referenced by the manifests in
+// paths and emits a diff.Result on events whenever the live object diverges
+// from its expanded, on-disk manifest. It returns the informers' stop
+// channel, which WatchDrift closes itself once ctx is done; callers must not
+// close it.
+func (k *KubeClient) WatchDrift(
+	ctx context.Context,
+	paths []string,
+	ignoreFields []string,
+	events chan<- diff.Result,
+) (chan struct{}, error) {
+	objs, err := util.ExpandedObjects(paths, k.clusterConfig.Subpaths)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := map[string]*unstructured.Unstructured{}
+	for _, obj := range objs {
+		gvr, err := k.gvrForObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		desired[driftKey(gvr.String(), obj.GetNamespace(), obj.GetName())] = obj
+	}
+
+	factory := dynamicinformer.NewDynamicSharedInformerFactory(k.dynamicClient, driftInformerResync)
+	stopCh := make(chan struct{})
+
+	checkDrift := func(obj interface{}) {
+		live, ok := obj.(*unstructured.Unstructured)
+		if !ok {
+			return
+		}
+
+		gvr, err := k.gvrForObject(live)
+		if err != nil {
+			log.Debugf("Skipping drift check for %s: %+v", live.GetName(), err)
+			return
+		}
+
+		wanted, ok := desired[driftKey(gvr.String(), live.GetNamespace(), live.GetName())]
+		if !ok {
+			// Not a kubeapply-managed object.
+			return
+		}
+
+		result, changed, err := diff.DiffUnstructured(live, wanted)
+		if err != nil {
+			log.Errorf("Error diffing %s/%s for drift: %+v", live.GetNamespace(), live.GetName(), err)
+			return
+		}
+
+		if !changed {
+			return
+		}
+
+		result, changed = diff.FilterIgnoredFieldsSingle(result, ignoreFields)
+		if changed {
+			events <- result
+		}
+	}
+
+	gvrs := map[string]bool{}
+	for _, obj := range objs {
+		gvr, err := k.gvrForObject(obj)
+		if err != nil {
+			return nil, err
+		}
+		gvrs[gvr.String()] = true
+
+		informer := factory.ForResource(gvr).Informer()
+		informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    checkDrift,
+			UpdateFunc: func(_, newObj interface{}) { checkDrift(newObj) },
+		})
+	}
+
+	factory.Start(stopCh)
+	factory.WaitForCacheSync(stopCh)
+
+	go func() {
+		<-ctx.Done()
+		close(stopCh)
+	}()
+
+	return stopCh, nil
+}
+
+func driftKey(gvr, namespace, name string) string {
+	return gvr + "/" + namespace + "/" + name
+}
@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// ResourceEvent is a structured record describing the processing of a single
+// resource during a diff or apply run, suitable for piping into a log
+// aggregator when the json or logfmt Format is configured.
+type ResourceEvent struct {
+	Stage          string        `json:"stage"`
+	Kind           string        `json:"kind"`
+	Namespace      string        `json:"namespace"`
+	Name           string        `json:"name"`
+	DiffLinesCount int           `json:"diff_lines_count,omitempty"`
+	Duration       time.Duration `json:"duration,omitempty"`
+}
+
+// LogResourceEvent emits a ResourceEvent as structured log fields, so that
+// "terraform apply" output piped into an aggregator can be filtered and
+// charted per-resource. The json and logfmt formats include these fields;
+// the terraform format prints only the "resource event" message.
+func LogResourceEvent(event ResourceEvent) {
+	log.WithFields(log.Fields{
+		"stage":            event.Stage,
+		"kind":             event.Kind,
+		"namespace":        event.Namespace,
+		"name":             event.Name,
+		"diff_lines_count": event.DiffLinesCount,
+		"duration":         event.Duration.String(),
+	}).Info("resource event")
+}
@@ -0,0 +1,59 @@
+// Package logging configures the logrus formatter used across the kubeapply
+// CLI and Terraform provider, so that both can emit either the
+// Terraform-style bracketed lines, JSON, or logfmt, selected by name.
+package logging
+
+import (
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Format is one of the supported log output formats.
+type Format string
+
+const (
+	// FormatTerraform renders "[LEVEL] message" lines, matching Terraform's
+	// convention for plugin log output.
+	FormatTerraform Format = "terraform"
+
+	// FormatJSON renders each log entry as a single JSON object.
+	FormatJSON Format = "json"
+
+	// FormatLogfmt renders each log entry as space-separated key=value pairs.
+	FormatLogfmt Format = "logfmt"
+)
+
+// Configure sets the global logrus formatter to match the named format. An
+// empty format selects FormatTerraform, which is the historical default for
+// both the CLI and the provider; an unrecognized format returns an error.
+func Configure(format string) error {
+	switch Format(strings.ToLower(format)) {
+	case FormatJSON:
+		log.SetFormatter(&log.JSONFormatter{})
+	case FormatLogfmt:
+		log.SetFormatter(&log.TextFormatter{DisableColors: true, FullTimestamp: true})
+	case FormatTerraform, "":
+		log.SetFormatter(&terraformFormatter{})
+	default:
+		return fmt.Errorf("unrecognized log format %q, expected one of: terraform, json, logfmt", format)
+	}
+
+	return nil
+}
+
+// terraformFormatter renders log lines the way Terraform expects them when
+// a provider's stderr is captured as plugin debug output; see
+// https://www.terraform.io/docs/extend/debugging.html#inserting-log-lines-into-a-provider
+type terraformFormatter struct{}
+
+func (t *terraformFormatter) Format(entry *log.Entry) ([]byte, error) {
+	return []byte(
+		fmt.Sprintf(
+			"[%s] %s\n",
+			strings.ToUpper(entry.Level.String()),
+			entry.Message,
+		),
+	), nil
+}
@@ -0,0 +1,55 @@
+// Package provider implements the Terraform provider for kubeapply.
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Provider returns the schema.Provider for kubeapply. The meta argument is
+// not used.
+func Provider(meta interface{}) *schema.Provider {
+	return &schema.Provider{
+		Schema: map[string]*schema.Schema{
+			"kubeconfig": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBECONFIG", nil),
+				Description: "Path to the kubeconfig used to connect to the cluster",
+			},
+			"context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBEAPPLY_CONTEXT", nil),
+				Description: "Kubeconfig context to use; defaults to the kubeconfig's current-context",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Namespace override applied to all resources managed by this provider",
+			},
+		},
+		ResourcesMap:         map[string]*schema.Resource{},
+		ConfigureContextFunc: configureProvider,
+	}
+}
+
+// providerConfig holds the resolved provider-level settings that are passed
+// down into each resource's cluster client.
+type providerConfig struct {
+	kubeConfig string
+	context    string
+	namespace  string
+}
+
+func configureProvider(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	config := &providerConfig{
+		kubeConfig: d.Get("kubeconfig").(string),
+		context:    d.Get("context").(string),
+		namespace:  d.Get("namespace").(string),
+	}
+
+	return config, nil
+}